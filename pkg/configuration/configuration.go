@@ -3,14 +3,22 @@
 package configuration
 
 import (
+	"context"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/codeready-toolchain/toolchain-common/pkg/configuration"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	toolscache "k8s.io/client-go/tools/cache"
+
 	errs "github.com/pkg/errors"
 	"github.com/spf13/viper"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -144,6 +152,52 @@ const (
 	varVerificationCodeExpiresInMin     = "verification.code_expires_in_min"
 	DefaultVerificationCodeExpiresInMin = 5
 
+	// varVerificationProvider selects which backend generates, stores and checks verification codes.
+	// Supported values are "self_managed" (codes are generated and tracked by this service) and
+	// "twilio_verify" (code lifecycle is delegated to the Twilio Verify API).
+	varVerificationProvider = "verification.provider"
+
+	// DefaultVerificationProvider is the default verification provider used when
+	// verification.provider is unset, preserving the behavior of existing installations.
+	DefaultVerificationProvider = "self_managed"
+
+	// varTwilioVerifyServiceSID is the constant used to read the configuration parameter for the
+	// Twilio Verify Service identifier, used when verification.provider is set to "twilio_verify"
+	varTwilioVerifyServiceSID = "twilio.verify.service_sid"
+
+	// varVerificationChannel selects how a self-managed verification code is delivered to the
+	// user: "sms", "call", or "sms_then_call" (SMS first, falling back to a voice call on re-send)
+	varVerificationChannel = "verification.channel"
+
+	// DefaultVerificationChannel is the default delivery channel used when verification.channel
+	// is unset, preserving the existing SMS-only behavior.
+	DefaultVerificationChannel = "sms"
+
+	// varVerificationCallRetryAfterSec is the number of seconds a user must wait after the
+	// initial SMS before requesting a re-send switches the channel to a voice call, when
+	// verification.channel is "sms_then_call"
+	varVerificationCallRetryAfterSec     = "verification.call_retry_after_sec"
+	DefaultVerificationCallRetryAfterSec = 60
+
+	// varSMSProvider selects the default SMS provider used to deliver verification codes:
+	// "twilio" or "sns". Overridden per destination country by sms.country_routes.
+	varSMSProvider = "sms.provider"
+
+	// DefaultSMSProvider is the default SMS provider used when sms.provider is unset,
+	// preserving the existing Twilio-only behavior.
+	DefaultSMSProvider = "twilio"
+
+	// varSMSCountryRoutes is a comma-separated list of COUNTRY=provider pairs, e.g.
+	// "US=twilio,IN=sns,*=twilio", used to route verification SMS to the cheapest or most
+	// reliable provider for a destination country. "*" is the catch-all for unlisted countries.
+	varSMSCountryRoutes = "sms.country_routes"
+
+	// varSNSAccessKeyID, varSNSSecretAccessKey and varSNSRegion are the constants used to read
+	// the AWS credentials and region used for sending verification SMS via AWS SNS
+	varSNSAccessKeyID     = "sns.access_key_id"
+	varSNSSecretAccessKey = "sns.secret_access_key"
+	varSNSRegion          = "sns.region"
+
 	// varWoopraDomain contains the woopra domain
 	varWoopraDomain = "woopra.domain"
 
@@ -179,14 +233,30 @@ type Configuration interface {
 	GetVerificationCodeExpiresInMin() int
 	GetWoopraDomain() string
 	GetSegmentWriteKey() string
+	GetVerificationProvider() string
+	GetTwilioVerifyServiceSID() string
+	GetVerificationChannel() string
+	GetVerificationCallRetryAfter() time.Duration
+	GetSMSProvider() string
+	GetSMSCountryRoutes() map[string]string
+	GetSNSAccessKeyID() string
+	GetSNSSecretAccessKey() string
+	GetSNSRegion() string
 }
 
 // Config encapsulates the Viper configuration registry which stores the
 // configuration data in-memory.
 type ViperConfig struct {
+	// mu guards v, secretValues and excludedDomains so that Watch can swap them atomically
+	// while Get* methods keep serving the previous values concurrently.
+	mu              sync.RWMutex
 	v               *viper.Viper
 	secretValues    map[string]string
 	excludedDomains []string
+
+	cl          client.Client
+	subscribers map[uint64]func(Configuration)
+	nextSubID   uint64
 }
 
 // LoadConfig loads the initial configuration.
@@ -202,7 +272,9 @@ func LoadConfig(cl client.Client) (*ViperConfig, error) {
 		return nil, err
 	}
 
-	return initConfig(secret), nil
+	c := initConfig(secret)
+	c.cl = cl
+	return c, nil
 }
 
 // initConfig creates an initial, empty configuration.
@@ -210,6 +282,7 @@ func initConfig(secret map[string]string) *ViperConfig {
 	c := ViperConfig{
 		v:            viper.New(),
 		secretValues: secret,
+		subscribers:  map[uint64]func(Configuration){},
 	}
 
 	c.v.SetEnvPrefix(EnvPrefix)
@@ -224,6 +297,122 @@ func initConfig(secret map[string]string) *ViperConfig {
 	return &c
 }
 
+// viperInstance returns the Viper instance currently backing this configuration. Reads are
+// guarded by mu so that a concurrent Watch reload can't be observed half-applied.
+func (c *ViperConfig) viperInstance() *viper.Viper {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.v
+}
+
+// secretValue returns the value of key from the Secret-backed configuration values currently
+// in effect.
+func (c *ViperConfig) secretValue(key string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.secretValues[key]
+}
+
+// Subscribe registers fn to be called, with the new configuration, whenever Watch applies a
+// reload, and returns an unsubscribe function that removes fn again. Subsystems that cache
+// derived state (e.g. the excluded-domains slice, or a Twilio client keyed on account SID)
+// should use this to drop that state when values change, and call the returned function when
+// that cached state (and the subscription itself) is no longer needed, so subscribers doesn't
+// grow for the life of the process.
+func (c *ViperConfig) Subscribe(fn func(Configuration)) func() {
+	c.mu.Lock()
+	id := c.nextSubID
+	c.nextSubID++
+	c.subscribers[id] = fn
+	c.mu.Unlock()
+
+	return func() {
+		c.mu.Lock()
+		delete(c.subscribers, id)
+		c.mu.Unlock()
+	}
+}
+
+// Watch starts a controller-runtime informer-backed watch on the HOST_OPERATOR_CONFIG_MAP_NAME
+// ConfigMap and HOST_OPERATOR_SECRET_NAME Secret in GetNamespace(), and on each event rebuilds
+// the in-memory configuration so that operators can flip verification.enabled, rotate Twilio
+// credentials, or update the excluded-domains list without restarting the registration service.
+// It blocks until ctx is cancelled.
+func (c *ViperConfig) Watch(ctx context.Context) error {
+	restCfg, err := ctrl.GetConfig()
+	if err != nil {
+		return errs.Wrap(err, "failed to load kube config for configuration watch")
+	}
+
+	cmName := os.Getenv("HOST_OPERATOR_CONFIG_MAP_NAME")
+	secretName := os.Getenv("HOST_OPERATOR_SECRET_NAME")
+
+	ca, err := ctrlcache.New(restCfg, ctrlcache.Options{
+		Namespace: c.GetNamespace(),
+		ByObject: map[client.Object]ctrlcache.ByObject{
+			&corev1.ConfigMap{}: {Field: fields.OneTermEqualSelector("metadata.name", cmName)},
+			&corev1.Secret{}:    {Field: fields.OneTermEqualSelector("metadata.name", secretName)},
+		},
+	})
+	if err != nil {
+		return errs.Wrap(err, "failed to create configuration watch cache")
+	}
+
+	cmInformer, err := ca.GetInformer(ctx, &corev1.ConfigMap{})
+	if err != nil {
+		return errs.Wrap(err, "failed to get ConfigMap informer")
+	}
+	secretInformer, err := ca.GetInformer(ctx, &corev1.Secret{})
+	if err != nil {
+		return errs.Wrap(err, "failed to get Secret informer")
+	}
+
+	handler := toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { c.reload() },
+		UpdateFunc: func(interface{}, interface{}) { c.reload() },
+		DeleteFunc: func(interface{}) { c.reload() },
+	}
+	if _, err := cmInformer.AddEventHandler(handler); err != nil {
+		return errs.Wrap(err, "failed to watch ConfigMap")
+	}
+	if _, err := secretInformer.AddEventHandler(handler); err != nil {
+		return errs.Wrap(err, "failed to watch Secret")
+	}
+
+	return ca.Start(ctx)
+}
+
+// reload rebuilds a fresh Viper instance and secretValues map from the host-operator ConfigMap
+// and Secret, and atomically swaps them in, then notifies every subscriber.
+func (c *ViperConfig) reload() {
+	secret, err := configuration.LoadFromSecret("HOST_OPERATOR_SECRET_NAME", c.cl)
+	if err != nil {
+		log.Error(err, "failed to reload configuration Secret")
+		return
+	}
+	if err := configuration.LoadFromConfigMap(EnvPrefix, "HOST_OPERATOR_CONFIG_MAP_NAME", c.cl); err != nil {
+		log.Error(err, "failed to reload configuration ConfigMap")
+		return
+	}
+
+	fresh := initConfig(secret)
+
+	c.mu.Lock()
+	c.v = fresh.v
+	c.secretValues = fresh.secretValues
+	c.excludedDomains = fresh.excludedDomains
+	subscribers := make([]func(Configuration), 0, len(c.subscribers))
+	for _, fn := range c.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	c.mu.Unlock()
+
+	log.Info("configuration reloaded")
+	for _, fn := range subscribers {
+		fn(c)
+	}
+}
+
 // New creates a configuration reader object using a configurable configuration
 // file path. If the provided config file path is empty, a default configuration
 // will be created.
@@ -251,9 +440,9 @@ func New(configFilePath string, cl client.Client) (Configuration, error) {
 
 func (c *ViperConfig) PrintConfig() {
 	logWithValuesRegServ := log
-	keys := c.v.AllKeys()
+	keys := c.viperInstance().AllKeys()
 	for _, key := range keys {
-		logWithValuesRegServ = logWithValuesRegServ.WithValues(key, c.v.Get(key))
+		logWithValuesRegServ = logWithValuesRegServ.WithValues(key, c.viperInstance().Get(key))
 	}
 
 	logWithValuesRegServ.Info("Registration service configuration variables:")
@@ -261,7 +450,7 @@ func (c *ViperConfig) PrintConfig() {
 
 // GetViperInstance returns the underlying Viper instance.
 func (c *ViperConfig) GetViperInstance() *viper.Viper {
-	return c.v
+	return c.viperInstance()
 }
 
 func (c *ViperConfig) setConfigDefaults() {
@@ -286,56 +475,60 @@ func (c *ViperConfig) setConfigDefaults() {
 	c.v.SetDefault(varVerificationAttemptsAllowed, DefaultVerificationAttemptsAllowed)
 	c.v.SetDefault(varVerificationMessageTemplate, DefaultVerificationMessageTemplate)
 	c.v.SetDefault(varVerificationCodeExpiresInMin, DefaultVerificationCodeExpiresInMin)
+	c.v.SetDefault(varVerificationProvider, DefaultVerificationProvider)
+	c.v.SetDefault(varVerificationChannel, DefaultVerificationChannel)
+	c.v.SetDefault(varVerificationCallRetryAfterSec, DefaultVerificationCallRetryAfterSec)
+	c.v.SetDefault(varSMSProvider, DefaultSMSProvider)
 }
 
 // GetHTTPAddress returns the HTTP address (as set via default, config file, or
 // environment variable) that the app-server binds to (e.g. "0.0.0.0:8080").
 func (c *ViperConfig) GetHTTPAddress() string {
-	return c.v.GetString(varHTTPAddress)
+	return c.viperInstance().GetString(varHTTPAddress)
 }
 
 // GetHTTPCompressResponses returns true if HTTP responses should be compressed
 // for clients that support it via the 'Accept-Encoding' header.
 func (c *ViperConfig) GetHTTPCompressResponses() bool {
-	return c.v.GetBool(varHTTPCompressResponses)
+	return c.viperInstance().GetBool(varHTTPCompressResponses)
 }
 
 // GetHTTPWriteTimeout returns the duration for the write timeout.
 func (c *ViperConfig) GetHTTPWriteTimeout() time.Duration {
-	return c.v.GetDuration(varHTTPWriteTimeout)
+	return c.viperInstance().GetDuration(varHTTPWriteTimeout)
 }
 
 // GetHTTPReadTimeout returns the duration for the read timeout.
 func (c *ViperConfig) GetHTTPReadTimeout() time.Duration {
-	return c.v.GetDuration(varHTTPReadTimeout)
+	return c.viperInstance().GetDuration(varHTTPReadTimeout)
 }
 
 // GetHTTPIdleTimeout returns the duration for the idle timeout.
 func (c *ViperConfig) GetHTTPIdleTimeout() time.Duration {
-	return c.v.GetDuration(varHTTPIdleTimeout)
+	return c.viperInstance().GetDuration(varHTTPIdleTimeout)
 }
 
 // GetEnvironment returns the environment such as prod, stage, unit-tests, e2e-tests, dev, etc
 func (c *ViperConfig) GetEnvironment() string {
-	return c.v.GetString(varEnvironment)
+	return c.viperInstance().GetString(varEnvironment)
 }
 
 // GetLogLevel returns the logging level (as set via config file or environment
 // variable).
 func (c *ViperConfig) GetLogLevel() string {
-	return c.v.GetString(varLogLevel)
+	return c.viperInstance().GetString(varLogLevel)
 }
 
 // IsLogJSON returns if we should log json format (as set via config file or
 // environment variable).
 func (c *ViperConfig) IsLogJSON() bool {
-	return c.v.GetBool(varLogJSON)
+	return c.viperInstance().GetBool(varLogJSON)
 }
 
 // GetGracefulTimeout returns the duration for which the server gracefully wait
 // for existing connections to finish - e.g. 15s or 1m.
 func (c *ViperConfig) GetGracefulTimeout() time.Duration {
-	return c.v.GetDuration(varGracefulTimeout)
+	return c.viperInstance().GetDuration(varGracefulTimeout)
 }
 
 // IsTestingMode returns if the service runs in unit-tests environment
@@ -346,86 +539,150 @@ func (c *ViperConfig) IsTestingMode() bool {
 // GetAuthClientLibraryURL returns the auth library location (as set via
 // config file or environment variable).
 func (c *ViperConfig) GetAuthClientLibraryURL() string {
-	return c.v.GetString(varAuthClientLibraryURL)
+	return c.viperInstance().GetString(varAuthClientLibraryURL)
 }
 
 // GetAuthClientConfigAuthContentType returns the auth config config content type (as
 // set via config file or environment variable).
 func (c *ViperConfig) GetAuthClientConfigAuthContentType() string {
-	return c.v.GetString(varAuthClientConfigContentType)
+	return c.viperInstance().GetString(varAuthClientConfigContentType)
 }
 
 func (c *ViperConfig) GetAuthClientConfigAuthRaw() string {
-	return c.v.GetString(varAuthClientConfigRaw)
+	return c.viperInstance().GetString(varAuthClientConfigRaw)
 }
 
 // GetTwilioAccountSID is the Twilio account identifier, used for sending phone verification messages
 func (c *ViperConfig) GetTwilioAccountSID() string {
-	return c.secretValues[varTwilioAccountSID]
+	return c.secretValue(varTwilioAccountSID)
 }
 
 // GetTwilioAuthToken is the Twilio authentication token, used for sending phone verification messages
 func (c *ViperConfig) GetTwilioAuthToken() string {
-	return c.secretValues[varTwilioAuthToken]
+	return c.secretValue(varTwilioAuthToken)
 }
 
 // GetAuthClientPublicKeysURL returns the public keys URL (as set via config file
 // or environment variable).
 func (c *ViperConfig) GetAuthClientPublicKeysURL() string {
-	return c.v.GetString(varAuthClientPublicKeysURL)
+	return c.viperInstance().GetString(varAuthClientPublicKeysURL)
 }
 
 // GetNamespace returns the namespace in which the registration service and host operator is running
 func (c *ViperConfig) GetNamespace() string {
-	return c.v.GetString(varNamespace)
+	return c.viperInstance().GetString(varNamespace)
 }
 
 // GetVerificationEnabled indicates whether the phone verification feature is enabled or not
 func (c *ViperConfig) GetVerificationEnabled() bool {
-	return c.v.GetBool(varVerificationEnabled)
+	return c.viperInstance().GetBool(varVerificationEnabled)
 }
 
 // GetVerificationDailyLimit is the number of times a user may initiate a phone verification request within a
 // 24 hour period
 func (c *ViperConfig) GetVerificationDailyLimit() int {
-	return c.v.GetInt(varVerificationDailyLimit)
+	return c.viperInstance().GetInt(varVerificationDailyLimit)
 }
 
 // GetVerificationAttemptsAllowed is the number of times a user may attempt to correctly enter a verification code,
 // if they fail then they must request another code
 func (c *ViperConfig) GetVerificationAttemptsAllowed() int {
-	return c.v.GetInt(varVerificationAttemptsAllowed)
+	return c.viperInstance().GetInt(varVerificationAttemptsAllowed)
 }
 
 // GetVerificationMessageTemplate is the message template used to generate the content sent to users via SMS for
 // phone verification
 func (c *ViperConfig) GetVerificationMessageTemplate() string {
-	return c.v.GetString(varVerificationMessageTemplate)
+	return c.viperInstance().GetString(varVerificationMessageTemplate)
 }
 
 // GetVerificationExcludedEmailDomains returns the list of email address domains for which phone verification
 // is not required
 func (c *ViperConfig) GetVerificationExcludedEmailDomains() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.excludedDomains
 }
 
 // GetTwilioFromNumber is the phone number or alphanumeric "Sender ID" for sending phone verification messages
 func (c *ViperConfig) GetTwilioFromNumber() string {
-	return c.secretValues[varTwilioFromNumber]
+	return c.secretValue(varTwilioFromNumber)
 }
 
 // GetVerificationCodeExpiresInMin returns an int representing the number of minutes before a verification code should
 // be expired
 func (c *ViperConfig) GetVerificationCodeExpiresInMin() int {
-	return c.v.GetInt(varVerificationCodeExpiresInMin)
+	return c.viperInstance().GetInt(varVerificationCodeExpiresInMin)
+}
+
+// GetVerificationProvider returns which backend is used to generate, store and check
+// phone verification codes: "self_managed" or "twilio_verify"
+func (c *ViperConfig) GetVerificationProvider() string {
+	return c.viperInstance().GetString(varVerificationProvider)
+}
+
+// GetTwilioVerifyServiceSID is the Twilio Verify Service identifier, used for starting and
+// checking phone verification challenges when verification.provider is "twilio_verify"
+func (c *ViperConfig) GetTwilioVerifyServiceSID() string {
+	return c.secretValue(varTwilioVerifyServiceSID)
+}
+
+// GetVerificationChannel returns how a self-managed verification code is delivered to the
+// user: "sms", "call", or "sms_then_call"
+func (c *ViperConfig) GetVerificationChannel() string {
+	return c.viperInstance().GetString(varVerificationChannel)
+}
+
+// GetVerificationCallRetryAfter returns the duration a user must wait after the initial SMS
+// before a re-send request switches the delivery channel to a voice call
+func (c *ViperConfig) GetVerificationCallRetryAfter() time.Duration {
+	return time.Duration(c.viperInstance().GetInt(varVerificationCallRetryAfterSec)) * time.Second
+}
+
+// GetSMSProvider returns the default SMS provider used to deliver verification codes,
+// overridden per destination country by GetSMSCountryRoutes
+func (c *ViperConfig) GetSMSProvider() string {
+	return c.viperInstance().GetString(varSMSProvider)
+}
+
+// GetSMSCountryRoutes returns the configured COUNTRY=provider routes, keyed by ISO country
+// code with "*" as the catch-all entry for countries with no specific route
+func (c *ViperConfig) GetSMSCountryRoutes() map[string]string {
+	routes := map[string]string{}
+	raw := strings.FieldsFunc(c.viperInstance().GetString(varSMSCountryRoutes), func(r rune) bool {
+		return r == ','
+	})
+	for _, route := range raw {
+		parts := strings.SplitN(route, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		routes[strings.ToUpper(strings.TrimSpace(parts[0]))] = strings.TrimSpace(parts[1])
+	}
+	return routes
+}
+
+// GetSNSAccessKeyID is the AWS access key ID used for sending verification SMS via AWS SNS
+func (c *ViperConfig) GetSNSAccessKeyID() string {
+	return c.secretValue(varSNSAccessKeyID)
+}
+
+// GetSNSSecretAccessKey is the AWS secret access key used for sending verification SMS via AWS SNS
+func (c *ViperConfig) GetSNSSecretAccessKey() string {
+	return c.secretValue(varSNSSecretAccessKey)
+}
+
+// GetSNSRegion is the AWS region used for sending verification SMS via AWS SNS
+func (c *ViperConfig) GetSNSRegion() string {
+	return c.viperInstance().GetString(varSNSRegion)
 }
 
 // GetWoopraDomain returns the woopra domain name
 func (c *ViperConfig) GetWoopraDomain() string {
-	return c.v.GetString(varWoopraDomain)
+	return c.viperInstance().GetString(varWoopraDomain)
 }
 
 // GetSegmentWriteKey returns the segment write key
 func (c *ViperConfig) GetSegmentWriteKey() string {
-	return c.v.GetString(varSegmentWriteKey)
+	return c.viperInstance().GetString(varSegmentWriteKey)
 }
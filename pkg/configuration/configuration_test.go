@@ -0,0 +1,72 @@
+package configuration
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// applyReload simulates what reload() does when the watched ConfigMap/Secret change, without
+// needing a live Kubernetes client: swap in fresh values and notify subscribers.
+func applyReload(c *ViperConfig, fresh *ViperConfig) {
+	c.mu.Lock()
+	c.v = fresh.v
+	c.secretValues = fresh.secretValues
+	c.excludedDomains = fresh.excludedDomains
+	subscribers := make([]func(Configuration), 0, len(c.subscribers))
+	for _, fn := range c.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	c.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(c)
+	}
+}
+
+func TestSubscribeIsNotifiedOnReload(t *testing.T) {
+	c := initConfig(map[string]string{})
+
+	var mu sync.Mutex
+	var notified Configuration
+	c.Subscribe(func(updated Configuration) {
+		mu.Lock()
+		notified = updated
+		mu.Unlock()
+	})
+
+	applyReload(c, initConfig(map[string]string{}))
+
+	mu.Lock()
+	defer mu.Unlock()
+	if notified == nil {
+		t.Fatal("expected subscriber to be notified after reload")
+	}
+}
+
+func TestGetAccessorsReflectReload(t *testing.T) {
+	c := initConfig(map[string]string{"twilio.account.sid": "old-sid"})
+	c.v.Set(varNamespace, "before")
+
+	if got := c.GetNamespace(); got != "before" {
+		t.Fatalf("got namespace %q, want %q", got, "before")
+	}
+	if got := c.GetTwilioAccountSID(); got != "old-sid" {
+		t.Fatalf("got Twilio SID %q, want %q", got, "old-sid")
+	}
+
+	fresh := viper.New()
+	fresh.Set(varNamespace, "after")
+	c.mu.Lock()
+	c.v = fresh
+	c.secretValues = map[string]string{"twilio.account.sid": "new-sid"}
+	c.mu.Unlock()
+
+	if got := c.GetNamespace(); got != "after" {
+		t.Fatalf("got namespace %q after reload, want %q", got, "after")
+	}
+	if got := c.GetTwilioAccountSID(); got != "new-sid" {
+		t.Fatalf("got Twilio SID %q after reload, want %q", got, "new-sid")
+	}
+}
@@ -0,0 +1,27 @@
+package configuration
+
+import "testing"
+
+func TestGetSMSCountryRoutesParsesRoutes(t *testing.T) {
+	c := initConfig(map[string]string{})
+	c.v.Set(varSMSCountryRoutes, "US=twilio, IN=sns,*=twilio")
+
+	routes := c.GetSMSCountryRoutes()
+	want := map[string]string{"US": "twilio", "IN": "sns", "*": "twilio"}
+	if len(routes) != len(want) {
+		t.Fatalf("got routes %v, want %v", routes, want)
+	}
+	for country, provider := range want {
+		if routes[country] != provider {
+			t.Errorf("route for %q = %q, want %q", country, routes[country], provider)
+		}
+	}
+}
+
+func TestGetSMSCountryRoutesEmptyWhenUnset(t *testing.T) {
+	c := initConfig(map[string]string{})
+
+	if routes := c.GetSMSCountryRoutes(); len(routes) != 0 {
+		t.Fatalf("got routes %v, want empty map", routes)
+	}
+}
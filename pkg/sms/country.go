@@ -0,0 +1,44 @@
+package sms
+
+import "strings"
+
+// callingCodeCountries maps E.164 calling code prefixes to an ISO country code. This is not
+// exhaustive - it covers the calling codes that are unambiguous from their prefix alone, which
+// is enough to route the markets operators care about. countryFromE164 picks the longest
+// matching prefix, so the order of entries doesn't matter.
+var callingCodeCountries = []struct {
+	prefix  string
+	country string
+}{
+	{"1", "US"},
+	{"91", "IN"},
+	{"44", "GB"},
+	{"49", "DE"},
+	{"33", "FR"},
+	{"81", "JP"},
+	{"86", "CN"},
+	{"61", "AU"},
+	{"55", "BR"},
+	{"27", "ZA"},
+	{"234", "NG"},
+	{"254", "KE"},
+	{"20", "EG"},
+	{"7", "RU"},
+}
+
+// countryFromE164 returns the ISO country code for phoneNumber, an E.164 formatted phone
+// number (e.g. "+15551234567"), by matching the longest known calling code prefix. It returns
+// an empty string if the calling code isn't in callingCodeCountries.
+func countryFromE164(phoneNumber string) string {
+	number := strings.TrimPrefix(phoneNumber, "+")
+
+	country := ""
+	matchLen := 0
+	for _, cc := range callingCodeCountries {
+		if strings.HasPrefix(number, cc.prefix) && len(cc.prefix) > matchLen {
+			country = cc.country
+			matchLen = len(cc.prefix)
+		}
+	}
+	return country
+}
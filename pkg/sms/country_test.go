@@ -0,0 +1,26 @@
+package sms
+
+import "testing"
+
+func TestCountryFromE164(t *testing.T) {
+	tests := map[string]struct {
+		phoneNumber string
+		want        string
+	}{
+		"US number":                    {phoneNumber: "+15551234567", want: "US"},
+		"India number, longest match":  {phoneNumber: "+919876543210", want: "IN"},
+		"Nigeria number, 3 digit code": {phoneNumber: "+2348012345678", want: "NG"},
+		"Russia number, 1 digit code":  {phoneNumber: "+79991234567", want: "RU"},
+		"missing leading plus":         {phoneNumber: "15551234567", want: "US"},
+		"unknown calling code":         {phoneNumber: "+9999999999", want: ""},
+		"empty number":                 {phoneNumber: "", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := countryFromE164(tc.phoneNumber); got != tc.want {
+				t.Errorf("countryFromE164(%q) = %q, want %q", tc.phoneNumber, got, tc.want)
+			}
+		})
+	}
+}
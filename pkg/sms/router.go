@@ -0,0 +1,58 @@
+package sms
+
+import (
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+)
+
+// countryRouteCatchAll is the routes key used for countries with no specific route.
+const countryRouteCatchAll = "*"
+
+// router sends each message through the Sender configured for the destination country,
+// falling back to the catch-all route, and finally to sms.provider, if none matches.
+type router struct {
+	cfg     configuration.Configuration
+	routes  map[string]string
+	senders map[string]Sender
+}
+
+func newRouter(cfg configuration.Configuration, routes map[string]string) (*router, error) {
+	r := &router{
+		cfg:     cfg,
+		routes:  routes,
+		senders: map[string]Sender{},
+	}
+	for _, provider := range routes {
+		if _, ok := r.senders[provider]; ok {
+			continue
+		}
+		sender, err := newProviderSender(cfg, provider)
+		if err != nil {
+			return nil, err
+		}
+		r.senders[provider] = sender
+	}
+	return r, nil
+}
+
+func (r *router) Send(phoneNumber, message string) error {
+	sender, err := r.senderFor(phoneNumber)
+	if err != nil {
+		return err
+	}
+	return sender.Send(phoneNumber, message)
+}
+
+// senderFor resolves phoneNumber to a country code and returns the Sender routed for it,
+// falling back to the catch-all route and then sms.provider's default Sender.
+func (r *router) senderFor(phoneNumber string) (Sender, error) {
+	country := countryFromE164(phoneNumber)
+
+	if provider, ok := r.routes[country]; country != "" && ok {
+		return r.senders[provider], nil
+	}
+	if provider, ok := r.routes[countryRouteCatchAll]; ok {
+		return r.senders[provider], nil
+	}
+	log.Info("no SMS route matched, falling back to default provider", "country", country)
+	return newProviderSender(r.cfg, r.cfg.GetSMSProvider())
+}
@@ -0,0 +1,49 @@
+// Package sms provides a pluggable abstraction over SMS delivery providers, so that
+// verification codes can be routed to whichever provider is cheapest or most reliable for a
+// given destination country.
+package sms
+
+import (
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+
+	errs "github.com/pkg/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("sms")
+
+const (
+	// ProviderTwilio sends messages via the Twilio Messages API.
+	ProviderTwilio = "twilio"
+	// ProviderSNS sends messages via AWS SNS.
+	ProviderSNS = "sns"
+)
+
+// Sender sends an SMS message to a phone number.
+type Sender interface {
+	Send(phoneNumber, message string) error
+}
+
+// NewSender creates the Sender configured by cfg. If sms.country_routes is set, the returned
+// Sender routes each message to the provider configured for the destination country code,
+// falling back to the "*" catch-all route, and then to sms.provider, for countries with no
+// specific route. Otherwise every message is sent via sms.provider.
+func NewSender(cfg configuration.Configuration) (Sender, error) {
+	routes := cfg.GetSMSCountryRoutes()
+	if len(routes) == 0 {
+		return newProviderSender(cfg, cfg.GetSMSProvider())
+	}
+	return newRouter(cfg, routes)
+}
+
+// newProviderSender creates the Sender for a single named provider.
+func newProviderSender(cfg configuration.Configuration, provider string) (Sender, error) {
+	switch provider {
+	case ProviderTwilio, "":
+		return newTwilioSender(cfg), nil
+	case ProviderSNS:
+		return newSNSSender(cfg), nil
+	default:
+		return nil, errs.Errorf("unknown SMS provider %q", provider)
+	}
+}
@@ -0,0 +1,40 @@
+package sms
+
+import (
+	"context"
+
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+
+	errs "github.com/pkg/errors"
+)
+
+// snsSender sends messages via AWS SNS using the credentials and region configured via the
+// host-operator Secret and ConfigMap.
+type snsSender struct {
+	cfg configuration.Configuration
+}
+
+func newSNSSender(cfg configuration.Configuration) *snsSender {
+	return &snsSender{cfg: cfg}
+}
+
+func (s *snsSender) Send(phoneNumber, message string) error {
+	client := sns.New(sns.Options{
+		Region: s.cfg.GetSNSRegion(),
+		Credentials: credentials.NewStaticCredentialsProvider(
+			s.cfg.GetSNSAccessKeyID(), s.cfg.GetSNSSecretAccessKey(), ""),
+	})
+
+	_, err := client.Publish(context.Background(), &sns.PublishInput{
+		Message:     aws.String(message),
+		PhoneNumber: aws.String(phoneNumber),
+	})
+	if err != nil {
+		return errs.Wrap(err, "failed to publish SNS SMS message")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package sms
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+
+	errs "github.com/pkg/errors"
+)
+
+const twilioSMSURLFormat = "https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json"
+
+// twilioSender sends messages via the Twilio Messages API using the account credentials
+// configured via the host-operator Secret.
+type twilioSender struct {
+	cfg configuration.Configuration
+}
+
+func newTwilioSender(cfg configuration.Configuration) *twilioSender {
+	return &twilioSender{cfg: cfg}
+}
+
+func (s *twilioSender) Send(phoneNumber, message string) error {
+	form := url.Values{}
+	form.Set("To", phoneNumber)
+	form.Set("From", s.cfg.GetTwilioFromNumber())
+	form.Set("Body", message)
+
+	reqURL := fmt.Sprintf(twilioSMSURLFormat, s.cfg.GetTwilioAccountSID())
+	req, err := http.NewRequest(http.MethodPost, reqURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errs.Wrap(err, "failed to create Twilio SMS request")
+	}
+	req.SetBasicAuth(s.cfg.GetTwilioAccountSID(), s.cfg.GetTwilioAuthToken())
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errs.Wrap(err, "failed to send Twilio SMS request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errs.Errorf("Twilio SMS request failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
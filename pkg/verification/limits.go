@@ -0,0 +1,67 @@
+package verification
+
+import (
+	"sync"
+	"time"
+
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+
+	errs "github.com/pkg/errors"
+)
+
+// limiter enforces the daily-limit and attempts-allowed counters on top of whichever backend
+// generates, stores and checks the actual verification codes. It's shared by both provider
+// implementations since Twilio Verify doesn't enforce either limit itself.
+type limiter struct {
+	cfg configuration.Configuration
+
+	mu      sync.Mutex
+	entries map[string]*limiterEntry
+}
+
+// limiterEntry tracks the limiter state for a single phone number.
+type limiterEntry struct {
+	dailyCount   int
+	windowStart  time.Time
+	attemptsMade int
+}
+
+func newLimiter(cfg configuration.Configuration) *limiter {
+	return &limiter{cfg: cfg, entries: map[string]*limiterEntry{}}
+}
+
+// allowStart enforces the daily limit for phoneNumber, resetting its rolling 24h window once
+// it has elapsed, and resets the attempts-made counter for the code about to be (re)sent.
+func (l *limiter) allowStart(phoneNumber string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[phoneNumber]
+	if !ok || time.Since(entry.windowStart) >= 24*time.Hour {
+		entry = &limiterEntry{windowStart: time.Now()}
+		l.entries[phoneNumber] = entry
+	}
+	if entry.dailyCount >= l.cfg.GetVerificationDailyLimit() {
+		return errs.New("daily verification limit reached for this phone number")
+	}
+	entry.dailyCount++
+	entry.attemptsMade = 0
+	return nil
+}
+
+// allowAttempt enforces the attempts-allowed limit for the verification code currently in
+// progress for phoneNumber.
+func (l *limiter) allowAttempt(phoneNumber string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, ok := l.entries[phoneNumber]
+	if !ok {
+		return errs.New("no verification in progress for this phone number")
+	}
+	if entry.attemptsMade >= l.cfg.GetVerificationAttemptsAllowed() {
+		return errs.New("no verification attempts remaining")
+	}
+	entry.attemptsMade++
+	return nil
+}
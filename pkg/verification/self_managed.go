@@ -0,0 +1,194 @@
+package verification
+
+import (
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+	"github.com/codeready-toolchain/registration-service/pkg/sms"
+
+	errs "github.com/pkg/errors"
+)
+
+const (
+	channelSMS         = "sms"
+	channelCall        = "call"
+	channelSMSThenCall = "sms_then_call"
+)
+
+// pendingCode tracks a single outstanding verification code generated for a phone number.
+type pendingCode struct {
+	code          string
+	expiresAt     time.Time
+	attemptsMade  int
+	attemptsTotal int
+	lastSentAt    time.Time
+}
+
+// selfManagedService generates, stores and checks verification codes itself, sending them to
+// the user via the configured sms.Sender, or by voice call for the "call" delivery channel.
+// The daily-limit counter is enforced via limiter; the attempts-allowed counter is tracked
+// directly on each pendingCode since it's generated and checked by this service anyway.
+type selfManagedService struct {
+	cfg     configuration.Configuration
+	limiter *limiter
+
+	// smsMu guards smsSender/smsSenderErr, which are rebuilt by Subscribe whenever
+	// sms.provider or sms.country_routes change on a hot reload.
+	smsMu        sync.RWMutex
+	smsSender    sms.Sender
+	smsSenderErr error
+
+	// unsubscribe removes the rebuildSMSSender registration made in newSelfManagedService. Set
+	// only when cfg supports hot-reload; called from Close when this instance is retired.
+	unsubscribe func()
+
+	mu    sync.Mutex
+	codes map[string]*pendingCode
+}
+
+func newSelfManagedService(cfg configuration.Configuration) *selfManagedService {
+	s := &selfManagedService{
+		cfg:     cfg,
+		limiter: newLimiter(cfg),
+		codes:   map[string]*pendingCode{},
+	}
+	s.rebuildSMSSender(cfg)
+	if w, ok := cfg.(watchableConfig); ok {
+		s.unsubscribe = w.Subscribe(s.rebuildSMSSender)
+	}
+	return s
+}
+
+// Close unsubscribes this instance's rebuildSMSSender registration from cfg, so that a
+// provider switch away from self_managed doesn't leak the subscription for the life of the
+// process. It has no effect on the in-progress codes/limiter state, which is simply discarded
+// along with the instance.
+func (s *selfManagedService) Close() {
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+}
+
+// rebuildSMSSender re-resolves the cached sms.Sender from cfg. Called once at construction
+// and again on every hot reload, so that changes to sms.provider or sms.country_routes take
+// effect without restarting the process.
+func (s *selfManagedService) rebuildSMSSender(cfg configuration.Configuration) {
+	smsSender, err := sms.NewSender(cfg)
+
+	s.smsMu.Lock()
+	s.smsSender = smsSender
+	s.smsSenderErr = err
+	s.smsMu.Unlock()
+}
+
+func (s *selfManagedService) StartVerification(phoneNumber string) error {
+	if err := s.limiter.allowStart(phoneNumber); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	pending, ok := s.codes[phoneNumber]
+	isResend := ok && time.Now().Before(pending.expiresAt)
+	channel, err := s.channelFor(pending, isResend)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+
+	if !isResend {
+		code, genErr := generateVerificationCode()
+		if genErr != nil {
+			s.mu.Unlock()
+			return errs.Wrap(genErr, "failed to generate verification code")
+		}
+		pending = &pendingCode{
+			code:          code,
+			expiresAt:     time.Now().Add(time.Duration(s.cfg.GetVerificationCodeExpiresInMin()) * time.Minute),
+			attemptsTotal: s.cfg.GetVerificationAttemptsAllowed(),
+		}
+		s.codes[phoneNumber] = pending
+	}
+	pending.lastSentAt = time.Now()
+	code := pending.code
+	s.mu.Unlock()
+
+	if channel == channelCall {
+		return s.placeCall(phoneNumber, code)
+	}
+
+	s.smsMu.RLock()
+	smsSender, smsSenderErr := s.smsSender, s.smsSenderErr
+	s.smsMu.RUnlock()
+	if smsSenderErr != nil {
+		return errs.Wrap(smsSenderErr, "failed to create SMS sender")
+	}
+	message := fmt.Sprintf(s.cfg.GetVerificationMessageTemplate(), code)
+	return smsSender.Send(phoneNumber, message)
+}
+
+// channelFor decides which delivery channel a verification code should be sent on. On the
+// first request for a phone number it's whatever verification.channel resolves to for an
+// initial send ("call" stays "call", both "sms" and "sms_then_call" start with "sms"). On a
+// re-send, "sms_then_call" switches to a voice call once verification.call_retry_after_sec
+// has elapsed since the previous send; any other channel just repeats itself.
+func (s *selfManagedService) channelFor(pending *pendingCode, isResend bool) (string, error) {
+	channel := s.cfg.GetVerificationChannel()
+	switch channel {
+	case channelSMS, channelCall, channelSMSThenCall, "":
+	default:
+		return "", errs.Errorf("unknown verification channel %q", channel)
+	}
+
+	if channel != channelSMSThenCall {
+		if channel == channelCall {
+			return channelCall, nil
+		}
+		return channelSMS, nil
+	}
+	if isResend && time.Since(pending.lastSentAt) >= s.cfg.GetVerificationCallRetryAfter() {
+		return channelCall, nil
+	}
+	return channelSMS, nil
+}
+
+func (s *selfManagedService) CheckCode(phoneNumber, code string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pending, ok := s.codes[phoneNumber]
+	if !ok {
+		return false, errs.New("no verification in progress for this phone number")
+	}
+	if time.Now().After(pending.expiresAt) {
+		delete(s.codes, phoneNumber)
+		return false, errs.New("verification code has expired")
+	}
+	if pending.attemptsMade >= pending.attemptsTotal {
+		delete(s.codes, phoneNumber)
+		return false, errs.New("no verification attempts remaining")
+	}
+
+	pending.attemptsMade++
+	if pending.code != code {
+		return false, nil
+	}
+
+	delete(s.codes, phoneNumber)
+	return true, nil
+}
+
+// generateVerificationCode returns a random 6 digit numeric verification code.
+func generateVerificationCode() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := make([]byte, 6)
+	for i, v := range b {
+		code[i] = '0' + v%10
+	}
+	return string(code), nil
+}
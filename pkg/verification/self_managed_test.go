@@ -0,0 +1,93 @@
+package verification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+)
+
+// fakeConfig satisfies configuration.Configuration for tests that only exercise a couple of
+// accessors; any unstubbed method call panics via the embedded nil interface.
+type fakeConfig struct {
+	configuration.Configuration
+	channel        string
+	callRetryAfter time.Duration
+}
+
+func (f fakeConfig) GetVerificationChannel() string {
+	return f.channel
+}
+
+func (f fakeConfig) GetVerificationCallRetryAfter() time.Duration {
+	return f.callRetryAfter
+}
+
+func TestChannelFor(t *testing.T) {
+	tests := map[string]struct {
+		channel     string
+		retryAfter  time.Duration
+		pending     *pendingCode
+		isResend    bool
+		wantChannel string
+		wantErr     bool
+	}{
+		"unset defaults to sms": {
+			channel:     "",
+			wantChannel: channelSMS,
+		},
+		"sms always sms": {
+			channel:     channelSMS,
+			isResend:    true,
+			pending:     &pendingCode{lastSentAt: time.Now()},
+			wantChannel: channelSMS,
+		},
+		"call always call": {
+			channel:     channelCall,
+			wantChannel: channelCall,
+		},
+		"sms_then_call initial send is sms": {
+			channel:     channelSMSThenCall,
+			isResend:    false,
+			wantChannel: channelSMS,
+		},
+		"sms_then_call resend before retry delay stays sms": {
+			channel:     channelSMSThenCall,
+			retryAfter:  time.Minute,
+			isResend:    true,
+			pending:     &pendingCode{lastSentAt: time.Now()},
+			wantChannel: channelSMS,
+		},
+		"sms_then_call resend after retry delay switches to call": {
+			channel:     channelSMSThenCall,
+			retryAfter:  time.Minute,
+			isResend:    true,
+			pending:     &pendingCode{lastSentAt: time.Now().Add(-2 * time.Minute)},
+			wantChannel: channelCall,
+		},
+		"unknown channel errors": {
+			channel: "carrier_pigeon",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &selfManagedService{cfg: fakeConfig{channel: tc.channel, callRetryAfter: tc.retryAfter}}
+
+			got, err := s.channelFor(tc.pending, tc.isResend)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.wantChannel {
+				t.Errorf("got channel %q, want %q", got, tc.wantChannel)
+			}
+		})
+	}
+}
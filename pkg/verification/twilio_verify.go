@@ -0,0 +1,102 @@
+package verification
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+
+	errs "github.com/pkg/errors"
+)
+
+const (
+	twilioVerifyStartURLFormat = "https://verify.twilio.com/v2/Services/%s/Verifications"
+	twilioVerifyCheckURLFormat = "https://verify.twilio.com/v2/Services/%s/VerificationCheck"
+
+	twilioVerifyStatusApproved = "approved"
+)
+
+// twilioVerifyResponse captures the fields of the Twilio Verify API response that we care
+// about. Twilio returns additional fields that we don't need and simply ignore.
+type twilioVerifyResponse struct {
+	Status string `json:"status"`
+}
+
+// twilioVerifyService delegates verification code generation, storage and expiry to the
+// Twilio Verify API, which does not enforce the daily-limit or attempts-allowed counters; those
+// are enforced here via limiter.
+type twilioVerifyService struct {
+	cfg     configuration.Configuration
+	limiter *limiter
+}
+
+func newTwilioVerifyService(cfg configuration.Configuration) *twilioVerifyService {
+	return &twilioVerifyService{cfg: cfg, limiter: newLimiter(cfg)}
+}
+
+func (s *twilioVerifyService) StartVerification(phoneNumber string) error {
+	if err := s.limiter.allowStart(phoneNumber); err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("To", phoneNumber)
+	form.Set("Channel", "sms")
+
+	reqURL := fmt.Sprintf(twilioVerifyStartURLFormat, s.cfg.GetTwilioVerifyServiceSID())
+	resp, err := s.do(reqURL, form)
+	if err != nil {
+		return errs.Wrap(err, "failed to start Twilio Verify verification")
+	}
+	if resp.Status == "" {
+		return errs.New("Twilio Verify did not return a verification status")
+	}
+	return nil
+}
+
+func (s *twilioVerifyService) CheckCode(phoneNumber, code string) (bool, error) {
+	if err := s.limiter.allowAttempt(phoneNumber); err != nil {
+		return false, err
+	}
+
+	form := url.Values{}
+	form.Set("To", phoneNumber)
+	form.Set("Code", code)
+
+	reqURL := fmt.Sprintf(twilioVerifyCheckURLFormat, s.cfg.GetTwilioVerifyServiceSID())
+	resp, err := s.do(reqURL, form)
+	if err != nil {
+		return false, errs.Wrap(err, "failed to check Twilio Verify verification code")
+	}
+	return resp.Status == twilioVerifyStatusApproved, nil
+}
+
+// do POSTs form to url using the Twilio account credentials and decodes the response body
+// into a twilioVerifyResponse. An HTTP status other than 200 is treated as a failed check.
+func (s *twilioVerifyService) do(reqURL string, form url.Values) (*twilioVerifyResponse, error) {
+	req, err := http.NewRequest(http.MethodPost, reqURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to create Twilio Verify request")
+	}
+	req.SetBasicAuth(s.cfg.GetTwilioAccountSID(), s.cfg.GetTwilioAuthToken())
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errs.Wrap(err, "failed to send Twilio Verify request")
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		// treat any non-200 response as a failed check/start, regardless of body content
+		return &twilioVerifyResponse{}, nil
+	}
+	var resp twilioVerifyResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, errs.Wrap(err, "failed to decode Twilio Verify response")
+	}
+	return &resp, nil
+}
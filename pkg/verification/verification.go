@@ -0,0 +1,130 @@
+// Package verification implements the phone verification flow used to confirm
+// that a user controls the phone number they registered with, before they are
+// granted access to the Developer Sandbox.
+package verification
+
+import (
+	"sync"
+
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+
+	errs "github.com/pkg/errors"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("verification")
+
+// Service starts and checks phone verification challenges for a user.
+type Service interface {
+	// StartVerification sends a new verification code to the given phone number.
+	StartVerification(phoneNumber string) error
+	// CheckCode validates a verification code entered by the user for the given
+	// phone number, returning true if the code is correct.
+	CheckCode(phoneNumber, code string) (bool, error)
+}
+
+// watchableConfig is implemented by configuration.ViperConfig. Subsystems that cache state
+// derived from config values (e.g. which verification provider is active, or an SMS client
+// keyed on account SID) use Subscribe to rebuild that state when a Watch(ctx) hot reload
+// changes the underlying ConfigMap/Secret, and call the returned unsubscribe function once
+// that cached state is discarded so the subscription doesn't outlive it.
+type watchableConfig interface {
+	Subscribe(fn func(configuration.Configuration)) (unsubscribe func())
+}
+
+// dynamicService wraps whichever provider implementation is currently selected by
+// verification.provider, and swaps it out when cfg is hot-reloaded and the provider actually
+// changes. Unrelated reloads (e.g. an excluded-domains or Twilio credential rotation) leave the
+// current implementation - and the in-progress codes/limiter state it holds - untouched.
+type dynamicService struct {
+	mu       sync.RWMutex
+	current  Service
+	provider string
+}
+
+// NewService creates the verification Service configured by cfg, selecting between the
+// self-managed and Twilio Verify backed implementations based on the
+// `verification.provider` config value. Both implementations enforce the daily-limit and
+// attempts-allowed counters themselves (see limiter); only code generation, storage and
+// expiry are delegated to Twilio Verify when that provider is selected. If cfg supports
+// hot-reload, the selected provider is rebuilt only when verification.provider itself changes;
+// any other provider instance is left in place, along with its pending codes and counters.
+func NewService(cfg configuration.Configuration) (Service, error) {
+	provider := normalizedProvider(cfg)
+	impl, err := newProviderService(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &dynamicService{current: impl, provider: provider}
+	if w, ok := cfg.(watchableConfig); ok {
+		w.Subscribe(d.applyConfig)
+	}
+	return d, nil
+}
+
+// applyConfig rebuilds the underlying provider implementation when updated's
+// verification.provider differs from the one currently in effect, and is a no-op otherwise so
+// that reloads unrelated to the provider choice don't discard pending verification state.
+func (d *dynamicService) applyConfig(updated configuration.Configuration) {
+	provider := normalizedProvider(updated)
+
+	d.mu.RLock()
+	unchanged := provider == d.provider
+	d.mu.RUnlock()
+	if unchanged {
+		return
+	}
+
+	newImpl, err := newProviderService(updated)
+	if err != nil {
+		log.Error(err, "failed to rebuild verification service after configuration reload")
+		return
+	}
+
+	d.mu.Lock()
+	old := d.current
+	d.current = newImpl
+	d.provider = provider
+	d.mu.Unlock()
+
+	if closer, ok := old.(interface{ Close() }); ok {
+		closer.Close()
+	}
+}
+
+// newProviderService creates the provider-specific Service implementation selected by cfg.
+func newProviderService(cfg configuration.Configuration) (Service, error) {
+	switch cfg.GetVerificationProvider() {
+	case configuration.DefaultVerificationProvider, "":
+		return newSelfManagedService(cfg), nil
+	case "twilio_verify":
+		return newTwilioVerifyService(cfg), nil
+	default:
+		return nil, errs.Errorf("unknown verification provider %q", cfg.GetVerificationProvider())
+	}
+}
+
+// normalizedProvider returns cfg's verification provider, mapping the unset value to the same
+// default newProviderService falls back to, so that switching between "" and the explicit
+// default name isn't mistaken for an actual provider change.
+func normalizedProvider(cfg configuration.Configuration) string {
+	if provider := cfg.GetVerificationProvider(); provider != "" {
+		return provider
+	}
+	return configuration.DefaultVerificationProvider
+}
+
+func (d *dynamicService) currentImpl() Service {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.current
+}
+
+func (d *dynamicService) StartVerification(phoneNumber string) error {
+	return d.currentImpl().StartVerification(phoneNumber)
+}
+
+func (d *dynamicService) CheckCode(phoneNumber, code string) (bool, error) {
+	return d.currentImpl().CheckCode(phoneNumber, code)
+}
@@ -0,0 +1,193 @@
+package verification
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/codeready-toolchain/registration-service/pkg/configuration"
+)
+
+// watchableFakeConfig is a fakeConfig that also implements watchableConfig, so tests can
+// simulate a configuration hot-reload without a real Kubernetes client.
+type watchableFakeConfig struct {
+	fakeConfig
+
+	mu          sync.Mutex
+	provider    string
+	nextSubID   int
+	subscribers map[int]func(configuration.Configuration)
+}
+
+func (f *watchableFakeConfig) GetVerificationProvider() string {
+	return f.provider
+}
+
+func (f *watchableFakeConfig) GetSMSProvider() string {
+	return ""
+}
+
+func (f *watchableFakeConfig) GetSMSCountryRoutes() map[string]string {
+	return nil
+}
+
+func (f *watchableFakeConfig) GetVerificationDailyLimit() int {
+	return 5
+}
+
+func (f *watchableFakeConfig) GetVerificationAttemptsAllowed() int {
+	return 3
+}
+
+func (f *watchableFakeConfig) GetVerificationCodeExpiresInMin() int {
+	return 5
+}
+
+func (f *watchableFakeConfig) GetVerificationMessageTemplate() string {
+	return "%s"
+}
+
+// recordingSender is an sms.Sender that records the last message instead of sending it.
+type recordingSender struct {
+	mu      sync.Mutex
+	message string
+}
+
+func (r *recordingSender) Send(phoneNumber, message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.message = message
+	return nil
+}
+
+func (f *watchableFakeConfig) Subscribe(fn func(configuration.Configuration)) func() {
+	f.mu.Lock()
+	if f.subscribers == nil {
+		f.subscribers = map[int]func(configuration.Configuration){}
+	}
+	id := f.nextSubID
+	f.nextSubID++
+	f.subscribers[id] = fn
+	f.mu.Unlock()
+
+	return func() {
+		f.mu.Lock()
+		delete(f.subscribers, id)
+		f.mu.Unlock()
+	}
+}
+
+// reload simulates a Watch(ctx) hot-reload: it notifies every subscriber with the current
+// configuration, as configuration.ViperConfig.reload does for real.
+func (f *watchableFakeConfig) reload() {
+	f.mu.Lock()
+	subscribers := make([]func(configuration.Configuration), 0, len(f.subscribers))
+	for _, fn := range f.subscribers {
+		subscribers = append(subscribers, fn)
+	}
+	f.mu.Unlock()
+	for _, fn := range subscribers {
+		fn(f)
+	}
+}
+
+// subscriberCount returns the number of currently-registered subscribers, for tests asserting
+// that reloads which don't change the provider don't leak new subscriptions.
+func (f *watchableFakeConfig) subscriberCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subscribers)
+}
+
+func TestNewServiceSwitchesProviderOnReload(t *testing.T) {
+	cfg := &watchableFakeConfig{provider: configuration.DefaultVerificationProvider}
+
+	svc, err := NewService(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d, ok := svc.(*dynamicService)
+	if !ok {
+		t.Fatalf("expected *dynamicService, got %T", svc)
+	}
+	if _, ok := d.currentImpl().(*selfManagedService); !ok {
+		t.Fatalf("expected self-managed provider initially, got %T", d.currentImpl())
+	}
+
+	cfg.provider = "twilio_verify"
+	cfg.reload()
+
+	if _, ok := d.currentImpl().(*twilioVerifyService); !ok {
+		t.Fatalf("expected twilio_verify provider after reload, got %T", d.currentImpl())
+	}
+}
+
+func TestSelfManagedServiceRebuildsSMSSenderOnReload(t *testing.T) {
+	cfg := &watchableFakeConfig{provider: configuration.DefaultVerificationProvider}
+
+	s := newSelfManagedService(cfg)
+	s.smsMu.RLock()
+	first := s.smsSender
+	s.smsMu.RUnlock()
+
+	cfg.reload()
+
+	s.smsMu.RLock()
+	second := s.smsSender
+	s.smsMu.RUnlock()
+
+	if first == second {
+		t.Fatalf("expected a new SMS sender instance after reload")
+	}
+}
+
+func TestNewServiceKeepsProviderInstanceWhenProviderUnchanged(t *testing.T) {
+	cfg := &watchableFakeConfig{provider: configuration.DefaultVerificationProvider}
+
+	svc, err := NewService(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d := svc.(*dynamicService)
+	before := d.currentImpl()
+
+	sender := &recordingSender{}
+	sm := before.(*selfManagedService)
+	sm.smsMu.Lock()
+	sm.smsSender = sender
+	sm.smsMu.Unlock()
+
+	if err := svc.StartVerification("+15551234567"); err != nil {
+		t.Fatalf("unexpected error starting verification: %v", err)
+	}
+
+	cfg.reload()
+
+	after := d.currentImpl()
+	if before != after {
+		t.Fatalf("expected the same provider instance after a reload that didn't change verification.provider")
+	}
+	sender.mu.Lock()
+	code := sender.message
+	sender.mu.Unlock()
+	if ok, err := svc.CheckCode("+15551234567", code); err != nil || !ok {
+		t.Fatalf("expected the code sent before the reload to be accepted, which only happens if the pending code survived the reload (ok=%v, err=%v)", ok, err)
+	}
+}
+
+func TestNewServiceDoesNotLeakSubscribersOnUnrelatedReloads(t *testing.T) {
+	cfg := &watchableFakeConfig{provider: configuration.DefaultVerificationProvider}
+
+	if _, err := NewService(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	before := cfg.subscriberCount()
+	for i := 0; i < 5; i++ {
+		cfg.reload()
+	}
+	after := cfg.subscriberCount()
+
+	if before != after {
+		t.Fatalf("expected subscriber count to stay at %d after unrelated reloads, got %d", before, after)
+	}
+}
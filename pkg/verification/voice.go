@@ -0,0 +1,45 @@
+package verification
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	errs "github.com/pkg/errors"
+)
+
+const (
+	twilioCallsURLFormat = "https://api.twilio.com/2010-04-01/Accounts/%s/Calls.json"
+
+	// twiMLFormat reads the verification code out to the user, pausing briefly first so the
+	// call has time to connect, and repeating it once in case it's missed the first time.
+	twiMLFormat = `<Response><Pause length="1"/><Say loop="2">Your Developer Sandbox verification code is <say-as interpret-as="digits">%s</say-as></Say></Response>`
+)
+
+// placeCall calls phoneNumber via the Twilio Voice API and reads code out using TwiML.
+func (s *selfManagedService) placeCall(phoneNumber, code string) error {
+	form := url.Values{}
+	form.Set("To", phoneNumber)
+	form.Set("From", s.cfg.GetTwilioFromNumber())
+	form.Set("Twiml", fmt.Sprintf(twiMLFormat, code))
+
+	reqURL := fmt.Sprintf(twilioCallsURLFormat, s.cfg.GetTwilioAccountSID())
+	req, err := http.NewRequest(http.MethodPost, reqURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return errs.Wrap(err, "failed to create Twilio Calls request")
+	}
+	req.SetBasicAuth(s.cfg.GetTwilioAccountSID(), s.cfg.GetTwilioAuthToken())
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errs.Wrap(err, "failed to send Twilio Calls request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errs.Errorf("Twilio Calls request failed with status %d", resp.StatusCode)
+	}
+	return nil
+}